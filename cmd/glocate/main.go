@@ -2,8 +2,12 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
 
@@ -13,6 +17,12 @@ import (
 	"github.com/Gosayram/go-locate/internal/version"
 )
 
+// exitInterrupted is the exit status used when a search is interrupted (128 + SIGINT)
+const exitInterrupted = 130
+
+// errInterrupted reports that the search was stopped by a signal
+var errInterrupted = errors.New("search interrupted")
+
 // rootCmd is the root command for glocate
 var rootCmd = &cobra.Command{
 	Use:   "glocate [pattern]",
@@ -38,17 +48,25 @@ var versionCmd = &cobra.Command{
 var (
 	cfgFile     string
 	advanced    bool
+	regex       bool
+	regexPath   bool
 	extensions  []string
 	size        string
 	mtime       string
 	content     string
+	contentBin  bool
+	contentMax  string
 	exclude     []string
 	include     []string
+	gitignore   bool
 	threads     int
 	depth       int
 	followLinks bool
 	format      string
+	color       bool
 	maxResults  int
+	sortKey     string
+	reverse     bool
 	verbose     bool
 )
 
@@ -62,23 +80,36 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.glocate.toml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 
+	registerSearchFlags(rootCmd)
+}
+
+// registerSearchFlags registers the search, performance and output flags on cmd
+func registerSearchFlags(cmd *cobra.Command) {
 	// Search flags
-	rootCmd.Flags().BoolVar(&advanced, "advanced", false, "enable advanced search mode with fuzzy matching")
-	rootCmd.Flags().StringSliceVar(&extensions, "ext", []string{}, "filter by file extensions (comma-separated)")
-	rootCmd.Flags().StringVar(&size, "size", "", "filter by file size (+100M, -1K)")
-	rootCmd.Flags().StringVar(&mtime, "mtime", "", "filter by modification time (-7d, +1h)")
-	rootCmd.Flags().StringVar(&content, "content", "", "search file content")
-	rootCmd.Flags().StringSliceVar(&exclude, "exclude", []string{}, "exclude directories")
-	rootCmd.Flags().StringSliceVar(&include, "include", []string{}, "include directories")
+	cmd.Flags().BoolVar(&advanced, "advanced", false, "enable advanced search mode with fuzzy matching")
+	cmd.Flags().BoolVar(&regex, "regex", false, "treat pattern as a regular expression")
+	cmd.Flags().BoolVar(&regexPath, "regex-full-path", false, "match regular expression against the full path")
+	cmd.Flags().StringSliceVar(&extensions, "ext", []string{}, "filter by file extensions (comma-separated)")
+	cmd.Flags().StringVar(&size, "size", "", "filter by file size (+100M, -1K)")
+	cmd.Flags().StringVar(&mtime, "mtime", "", "filter by modification time (-7d, +1h)")
+	cmd.Flags().StringVar(&content, "content", "", "search file content")
+	cmd.Flags().BoolVar(&contentBin, "content-binary", false, "include binary files in content search")
+	cmd.Flags().StringVar(&contentMax, "content-max-size", "", "max file size for content search (default 10M)")
+	cmd.Flags().StringSliceVar(&exclude, "exclude", []string{}, "exclude directories")
+	cmd.Flags().StringSliceVar(&include, "include", []string{}, "include directories")
+	cmd.Flags().BoolVar(&gitignore, "use-gitignore", false, "skip files matched by .gitignore files")
 
 	// Performance flags
-	rootCmd.Flags().IntVar(&threads, "threads", 0, "number of threads (default: CPU cores)")
-	rootCmd.Flags().IntVar(&depth, "depth", 0, "maximum search depth (0 = unlimited)")
-	rootCmd.Flags().BoolVar(&followLinks, "follow-symlinks", false, "follow symbolic links")
+	cmd.Flags().IntVar(&threads, "threads", 0, "number of threads (default: CPU cores)")
+	cmd.Flags().IntVar(&depth, "depth", 0, "maximum search depth (0 = unlimited)")
+	cmd.Flags().BoolVar(&followLinks, "follow-symlinks", false, "follow symbolic links")
 
 	// Output flags
-	rootCmd.Flags().StringVar(&format, "format", "path", "output format (path, detailed, json)")
-	rootCmd.Flags().IntVar(&maxResults, "max-results", config.DefaultMaxResults, "maximum number of results")
+	cmd.Flags().StringVar(&format, "format", "path", "output format (path, detailed, json, null, csv)")
+	cmd.Flags().BoolVar(&color, "color", true, "enable colored output")
+	cmd.Flags().IntVar(&maxResults, "max-results", config.DefaultMaxResults, "maximum number of results (0 = unlimited)")
+	cmd.Flags().StringVar(&sortKey, "sort", "", "sort results by key (name, path, size, mtime)")
+	cmd.Flags().BoolVar(&reverse, "reverse", false, "reverse the sort order")
 }
 
 func initConfig() {
@@ -93,7 +124,44 @@ func initConfig() {
 	}
 }
 
-func runSearch(_ *cobra.Command, args []string) error {
+// applyConfigDefaults fills flags that were not set on the command line
+// with values from the loaded configuration file. Keys missing from the
+// file leave the flag defaults untouched.
+func applyConfigDefaults(cmd *cobra.Command, cfg *config.Config) {
+	flags := cmd.Flags()
+
+	// fromFile reports whether flag was left unset and key comes from the config file
+	fromFile := func(flag, key string) bool {
+		return !flags.Changed(flag) && config.InFile(key)
+	}
+
+	if fromFile("exclude", "search.exclude_dirs") {
+		exclude = cfg.Search.ExcludeDirs
+	}
+	if fromFile("include", "search.include_dirs") {
+		include = cfg.Search.IncludeDirs
+	}
+	if fromFile("depth", "search.max_depth") {
+		depth = cfg.Search.MaxDepth
+	}
+	if fromFile("follow-symlinks", "search.follow_symlinks") {
+		followLinks = cfg.Search.FollowSymlinks
+	}
+	if fromFile("threads", "search.default_threads") {
+		threads = cfg.Search.DefaultThreads
+	}
+	if fromFile("format", "output.format") && cfg.Output.Format != "" {
+		format = cfg.Output.Format
+	}
+	if fromFile("color", "output.color") {
+		color = cfg.Output.Color
+	}
+	if fromFile("max-results", "output.max_results") && cfg.Output.MaxResults > 0 {
+		maxResults = cfg.Output.MaxResults
+	}
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
 	var pattern string
 	if len(args) > 0 {
 		pattern = args[0]
@@ -103,21 +171,31 @@ func runSearch(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("search pattern is required")
 	}
 
+	// Command-line flags take precedence over the config file
+	applyConfigDefaults(cmd, config.Get())
+
 	// Create search configuration
 	searchConfig := &search.Config{
-		Pattern:     pattern,
-		Advanced:    advanced,
-		Extensions:  extensions,
-		Size:        size,
-		Mtime:       mtime,
-		Content:     content,
-		Exclude:     exclude,
-		Include:     include,
-		Threads:     threads,
-		Depth:       depth,
-		FollowLinks: followLinks,
-		MaxResults:  maxResults,
-		Verbose:     verbose,
+		Pattern:        pattern,
+		Advanced:       advanced,
+		Regex:          regex,
+		RegexFullPath:  regexPath,
+		Extensions:     extensions,
+		Size:           size,
+		Mtime:          mtime,
+		Content:        content,
+		ContentBinary:  contentBin,
+		ContentMaxSize: contentMax,
+		Exclude:        exclude,
+		Include:        include,
+		Threads:        threads,
+		Depth:          depth,
+		FollowLinks:    followLinks,
+		UseGitignore:   gitignore,
+		MaxResults:     maxResults,
+		Sort:           sortKey,
+		Reverse:        reverse,
+		Verbose:        verbose,
 	}
 
 	// Create searcher
@@ -126,9 +204,14 @@ func runSearch(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create searcher: %w", err)
 	}
 
-	// Perform search
-	results, err := searcher.Search()
-	if err != nil {
+	// Stop the search promptly on Ctrl-C or termination
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Perform search; on interruption the partial results are still printed
+	results, err := searcher.SearchContext(ctx)
+	interrupted := errors.Is(err, context.Canceled)
+	if err != nil && !interrupted {
 		return fmt.Errorf("search failed: %w", err)
 	}
 
@@ -136,14 +219,27 @@ func runSearch(_ *cobra.Command, args []string) error {
 	outputConfig := &output.Config{
 		Format:  format,
 		Verbose: verbose,
+		Color:   color,
 	}
 
 	formatter := output.New(outputConfig)
-	return formatter.Print(results)
+	if err := formatter.Print(results); err != nil {
+		return err
+	}
+
+	if interrupted {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return errInterrupted
+	}
+	return nil
 }
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
+		if errors.Is(err, errInterrupted) {
+			os.Exit(exitInterrupted)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Gosayram/go-locate/internal/config"
+)
+
+const testConfig = `
+[search]
+exclude_dirs = ["node_modules", ".git"]
+include_dirs = ["/srv", "/opt"]
+max_depth = 5
+follow_symlinks = true
+default_threads = 3
+
+[output]
+format = "detailed"
+color = false
+max_results = 50
+`
+
+// loadTestConfig writes contents to a temporary TOML file and loads it
+func loadTestConfig(t *testing.T, contents string) *config.Config {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), ".glocate.toml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600), "Failed to write config file")
+
+	config.Reset()
+	t.Cleanup(config.Reset)
+	config.SetConfigFile(path)
+	require.NoError(t, config.Load(), "Failed to load config file")
+
+	return config.Get()
+}
+
+// newTestCommand returns a command with freshly registered search flags parsed from args
+func newTestCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+
+	cmd := &cobra.Command{Use: "glocate"}
+	registerSearchFlags(cmd)
+	require.NoError(t, cmd.Flags().Parse(args), "Failed to parse flags")
+
+	return cmd
+}
+
+func TestApplyConfigDefaultsFromFile(t *testing.T) {
+	cfg := loadTestConfig(t, testConfig)
+	cmd := newTestCommand(t)
+
+	applyConfigDefaults(cmd, cfg)
+
+	assert.Equal(t, []string{"node_modules", ".git"}, exclude, "Expected exclude from config")
+	assert.Equal(t, []string{"/srv", "/opt"}, include, "Expected include from config")
+	assert.Equal(t, 5, depth, "Expected depth from config")
+	assert.True(t, followLinks, "Expected follow symlinks from config")
+	assert.Equal(t, 3, threads, "Expected threads from config")
+	assert.Equal(t, "detailed", format, "Expected format from config")
+	assert.False(t, color, "Expected color from config")
+	assert.Equal(t, 50, maxResults, "Expected max results from config")
+}
+
+func TestApplyConfigDefaultsFlagsOverride(t *testing.T) {
+	cfg := loadTestConfig(t, testConfig)
+	cmd := newTestCommand(t,
+		"--exclude", "vendor",
+		"--include", "/home",
+		"--depth", "0",
+		"--follow-symlinks=false",
+		"--threads", "8",
+		"--format", "json",
+		"--color",
+		"--max-results", "1000",
+	)
+
+	applyConfigDefaults(cmd, cfg)
+
+	assert.Equal(t, []string{"vendor"}, exclude, "Expected exclude from flag")
+	assert.Equal(t, []string{"/home"}, include, "Expected include from flag")
+	assert.Equal(t, 0, depth, "Expected explicit zero depth from flag")
+	assert.False(t, followLinks, "Expected explicit false follow symlinks from flag")
+	assert.Equal(t, 8, threads, "Expected threads from flag")
+	assert.Equal(t, "json", format, "Expected format from flag")
+	assert.True(t, color, "Expected color from flag")
+	assert.Equal(t, config.DefaultMaxResults, maxResults, "Expected explicit default max results from flag")
+}
+
+func TestApplyConfigDefaultsPartialFile(t *testing.T) {
+	cfg := loadTestConfig(t, `
+[output]
+max_results = 10
+`)
+	cmd := newTestCommand(t, "--threads", "2")
+
+	applyConfigDefaults(cmd, cfg)
+
+	assert.Equal(t, 10, maxResults, "Expected max results from config")
+	assert.Equal(t, 2, threads, "Expected threads from flag")
+	assert.Equal(t, 0, depth, "Expected flag default depth")
+	assert.Empty(t, exclude, "Expected flag default exclude")
+	assert.Equal(t, "path", format, "Expected flag default format")
+	assert.True(t, color, "Expected flag default color")
+}
+
+func TestApplyConfigDefaultsWithoutFile(t *testing.T) {
+	config.Reset()
+	t.Cleanup(config.Reset)
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	require.NoError(t, config.Load(), "Expected missing config file to be ignored")
+
+	cmd := newTestCommand(t)
+	applyConfigDefaults(cmd, config.Get())
+
+	assert.Empty(t, exclude, "Expected flag default exclude")
+	assert.Empty(t, include, "Expected flag default include")
+	assert.Equal(t, 0, depth, "Expected flag default depth")
+	assert.Equal(t, 0, threads, "Expected flag default threads")
+	assert.False(t, followLinks, "Expected flag default follow symlinks")
+	assert.Equal(t, "path", format, "Expected flag default format")
+	assert.True(t, color, "Expected flag default color")
+	assert.Equal(t, config.DefaultMaxResults, maxResults, "Expected flag default max results")
+}
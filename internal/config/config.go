@@ -87,6 +87,19 @@ func Get() *Config {
 	return cfg
 }
 
+// InFile reports whether key was set by the loaded config file
+// rather than by a built-in default
+func InFile(key string) bool {
+	return viper.InConfig(key)
+}
+
+// Reset discards the loaded configuration and config file path
+func Reset() {
+	viper.Reset()
+	cfg = nil
+	configFile = ""
+}
+
 const (
 	// DefaultMaxDepth is the default maximum search depth
 	DefaultMaxDepth = 20
@@ -97,7 +110,7 @@ const (
 // setDefaults sets default configuration values
 func setDefaults() {
 	// Search defaults
-	viper.SetDefault("search.exclude_dirs", []string{"/proc", "/sys", "/dev", "/tmp"})
+	viper.SetDefault("search.exclude_dirs", []string{}) // system directories are skipped by the searcher
 	viper.SetDefault("search.include_dirs", []string{})
 	viper.SetDefault("search.max_depth", DefaultMaxDepth)
 	viper.SetDefault("search.follow_symlinks", false)
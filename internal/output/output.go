@@ -2,10 +2,13 @@
 package output
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,11 +20,14 @@ type Config struct {
 	Format  string
 	Verbose bool
 	Color   bool
+	// Writer receives the results; defaults to os.Stdout
+	Writer io.Writer
 }
 
 // Formatter handles result output formatting
 type Formatter struct {
 	config *Config
+	out    io.Writer
 }
 
 // New creates a new formatter instance
@@ -32,14 +38,27 @@ func New(config *Config) *Formatter {
 			Color:  true,
 		}
 	}
-	return &Formatter{config: config}
+	out := config.Writer
+	if out == nil {
+		out = os.Stdout
+	}
+	return &Formatter{config: config, out: out}
 }
 
 // Print outputs the search results
 func (f *Formatter) Print(results []*search.Result) error {
+	// Machine formats always go through their printer so CSV keeps its header
+	switch f.config.Format {
+	case "null", "path0":
+		return f.printNull(results)
+	case "csv":
+		return f.printCSV(results)
+	}
+
 	if len(results) == 0 {
+		// Notices go to stderr so they never mix with the results stream
 		if f.config.Verbose {
-			fmt.Println("No results found")
+			fmt.Fprintln(os.Stderr, "No results found")
 		}
 		return nil
 	}
@@ -57,15 +76,54 @@ func (f *Formatter) Print(results []*search.Result) error {
 // printPath prints only the file paths
 func (f *Formatter) printPath(results []*search.Result) error {
 	for _, result := range results {
+		var err error
 		if f.config.Color && result.IsDir {
-			fmt.Printf("\033[34m%s\033[0m\n", result.Path) // Blue for directories
+			_, err = fmt.Fprintf(f.out, "\033[34m%s\033[0m\n", result.Path) // Blue for directories
 		} else {
-			fmt.Println(result.Path)
+			_, err = fmt.Fprintln(f.out, result.Path)
+		}
+		if err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// printNull prints file paths terminated by NUL bytes for use with xargs -0
+func (f *Formatter) printNull(results []*search.Result) error {
+	for _, result := range results {
+		if _, err := io.WriteString(f.out, result.Path+"\x00"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printCSV prints results as CSV with a header row
+func (f *Formatter) printCSV(results []*search.Result) error {
+	writer := csv.NewWriter(f.out)
+
+	if err := writer.Write([]string{"path", "size", "mod_time", "is_dir", "mode"}); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		record := []string{
+			result.Path,
+			strconv.FormatInt(result.Size, 10),
+			result.ModTime.Format(time.RFC3339),
+			strconv.FormatBool(result.IsDir),
+			result.Mode,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
 // printDetailed prints detailed information about each result
 func (f *Formatter) printDetailed(results []*search.Result) error {
 	for _, result := range results {
@@ -79,25 +137,24 @@ func (f *Formatter) printDetailed(results []*search.Result) error {
 		sizeStr := f.formatSize(result.Size)
 		timeStr := result.ModTime.Format("2006-01-02 15:04:05")
 
-		if f.config.Color {
-			if result.IsDir {
-				fmt.Printf("\033[34m%-4s\033[0m %8s %s \033[34m%s\033[0m\n",
-					typeStr, sizeStr, timeStr, result.Path)
-			} else {
-				fmt.Printf("%-4s %8s %s %s\n",
-					typeStr, sizeStr, timeStr, result.Path)
-			}
+		var err error
+		if f.config.Color && result.IsDir {
+			_, err = fmt.Fprintf(f.out, "\033[34m%-4s\033[0m %8s %s \033[34m%s\033[0m\n",
+				typeStr, sizeStr, timeStr, result.Path)
 		} else {
-			fmt.Printf("%-4s %8s %s %s\n",
+			_, err = fmt.Fprintf(f.out, "%-4s %8s %s %s\n",
 				typeStr, sizeStr, timeStr, result.Path)
 		}
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // printJSON prints results in JSON format
 func (f *Formatter) printJSON(results []*search.Result) error {
-	encoder := json.NewEncoder(os.Stdout)
+	encoder := json.NewEncoder(f.out)
 	encoder.SetIndent("", "  ")
 
 	output := map[string]interface{}{
@@ -0,0 +1,92 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Gosayram/go-locate/internal/search"
+)
+
+// testResults returns a fixed set of results for formatter tests
+func testResults() []*search.Result {
+	modTime := time.Date(2025, 1, 22, 10, 30, 0, 0, time.UTC)
+
+	return []*search.Result{
+		{Path: "/srv/My Documents", Size: 4096, ModTime: modTime, IsDir: true, Mode: "drwxr-xr-x"},
+		{Path: "/srv/My Documents/report final.txt", Size: 1234, ModTime: modTime, Mode: "-rw-r--r--"},
+		{Path: "/srv/data/a,b.csv", Size: 0, ModTime: modTime, Mode: "-rw-------"},
+	}
+}
+
+func TestPrintNull(t *testing.T) {
+	for _, format := range []string{"null", "path0"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			formatter := New(&Config{Format: format, Color: true, Writer: &buf})
+
+			require.NoError(t, formatter.Print(testResults()), "Expected no error printing results")
+
+			expected := "/srv/My Documents\x00" +
+				"/srv/My Documents/report final.txt\x00" +
+				"/srv/data/a,b.csv\x00"
+			assert.Equal(t, expected, buf.String(), "Expected NUL-terminated paths without color codes")
+		})
+	}
+}
+
+func TestPrintCSV(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := New(&Config{Format: "csv", Color: true, Writer: &buf})
+
+	require.NoError(t, formatter.Print(testResults()), "Expected no error printing results")
+	assert.NotContains(t, buf.String(), "\033[", "Expected no color codes in CSV output")
+	assert.Contains(t, buf.String(), `"/srv/data/a,b.csv"`, "Expected path containing a comma to be quoted")
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err, "Expected well-formed CSV")
+
+	assert.Equal(t, [][]string{
+		{"path", "size", "mod_time", "is_dir", "mode"},
+		{"/srv/My Documents", "4096", "2025-01-22T10:30:00Z", "true", "drwxr-xr-x"},
+		{"/srv/My Documents/report final.txt", "1234", "2025-01-22T10:30:00Z", "false", "-rw-r--r--"},
+		{"/srv/data/a,b.csv", "0", "2025-01-22T10:30:00Z", "false", "-rw-------"},
+	}, records, "CSV records should match expected")
+}
+
+func TestPrintPath(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := New(&Config{Format: "path", Color: true, Writer: &buf})
+
+	require.NoError(t, formatter.Print(testResults()), "Expected no error printing results")
+
+	expected := "\033[34m/srv/My Documents\033[0m\n" +
+		"/srv/My Documents/report final.txt\n" +
+		"/srv/data/a,b.csv\n"
+	assert.Equal(t, expected, buf.String(), "Expected directories to be colored")
+}
+
+func TestPrintNoResults(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"csv", "path,size,mod_time,is_dir,mode\n"},
+		{"null", ""},
+		{"path", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			formatter := New(&Config{Format: tt.format, Verbose: true, Writer: &buf})
+
+			require.NoError(t, formatter.Print(nil), "Expected no error printing empty results")
+			assert.Equal(t, tt.expected, buf.String(), "Expected no notice in the results stream")
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package search
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+)
+
+// Content search constants
+const (
+	// defaultContentMaxSize is the largest file searched for content when no cap is configured
+	defaultContentMaxSize = 10 * sizeMegabyte
+	// binaryCheckSize is the number of leading bytes inspected for NUL bytes
+	binaryCheckSize = 8000
+	// contentScanBufferSize is the initial line buffer size for content scanning
+	contentScanBufferSize = 64 * sizeKilobyte
+)
+
+// contentMatcher searches file contents for a substring or regular expression
+type contentMatcher struct {
+	substring []byte
+	regex     *regexp.Regexp
+	maxSize   int64
+	binary    bool
+	follow    bool
+	verbose   bool
+}
+
+// newContentMatcher builds a content matcher from the search configuration.
+// It returns nil when content search is not requested.
+func newContentMatcher(config *Config) (*contentMatcher, error) {
+	if config.Content == "" {
+		return nil, nil
+	}
+
+	matcher := &contentMatcher{
+		maxSize: defaultContentMaxSize,
+		binary:  config.ContentBinary,
+		follow:  config.FollowLinks,
+		verbose: config.Verbose,
+	}
+
+	if config.ContentMaxSize != "" {
+		maxSize, err := parseByteCount(config.ContentMaxSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid content max size %q: %w", config.ContentMaxSize, err)
+		}
+		matcher.maxSize = maxSize
+	}
+
+	if config.Regex {
+		regex, err := regexp.Compile(config.Content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid content regex %q: %w", config.Content, err)
+		}
+		matcher.regex = regex
+	} else {
+		matcher.substring = []byte(config.Content)
+	}
+
+	return matcher, nil
+}
+
+// match reports whether the regular file at path contains the searched content
+func (m *contentMatcher) match(path string, info os.FileInfo) bool {
+	info = m.searchable(path, info)
+	if info == nil {
+		return false
+	}
+
+	file, err := os.Open(path) // #nosec G304 -- path comes from the directory walk
+	if err != nil {
+		if m.verbose {
+			fmt.Fprintf(os.Stderr, "Warning: Cannot read %s: %v\n", path, err)
+		}
+		return false
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	reader := bufio.NewReaderSize(file, binaryCheckSize)
+	if !m.binary && isBinary(reader) {
+		return false
+	}
+
+	// A single line may span the whole file, so let the scanner grow up to the size cap
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, min(info.Size()+1, contentScanBufferSize)), int(m.maxSize)+1)
+	for scanner.Scan() {
+		if m.matchLine(scanner.Bytes()) {
+			return true
+		}
+	}
+
+	if err := scanner.Err(); err != nil && m.verbose {
+		fmt.Fprintf(os.Stderr, "Warning: Cannot read %s: %v\n", path, err)
+	}
+
+	return false
+}
+
+// searchable returns the info of the regular file to search at path, or nil
+// if the entry is not a regular file or exceeds the size cap
+func (m *contentMatcher) searchable(path string, info os.FileInfo) os.FileInfo {
+	// The walk reports links themselves, so look through them when following
+	if m.follow && info != nil && info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Stat(path)
+		if err != nil {
+			return nil
+		}
+		info = target
+	}
+
+	if info == nil || !info.Mode().IsRegular() || info.Size() > m.maxSize {
+		return nil
+	}
+	return info
+}
+
+// isBinary reports whether the leading block of reader contains a NUL byte.
+// Unreadable content is treated as binary so it is skipped.
+func isBinary(reader *bufio.Reader) bool {
+	head, err := reader.Peek(binaryCheckSize)
+	if err != nil && err != io.EOF {
+		return true
+	}
+	return bytes.IndexByte(head, 0) >= 0
+}
+
+// matchLine reports whether a single line contains the searched content
+func (m *contentMatcher) matchLine(line []byte) bool {
+	if m.regex != nil {
+		return m.regex.Match(line)
+	}
+	return bytes.Contains(line, m.substring)
+}
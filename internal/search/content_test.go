@@ -0,0 +1,178 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeContentFiles creates files with the given contents in a temp directory
+func writeContentFiles(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	for name, data := range files {
+		path := filepath.Join(tempDir, name)
+		require.NoError(t, os.WriteFile(path, data, 0600), "Failed to write %s", name)
+	}
+
+	return tempDir
+}
+
+// contentMatches reports whether the named file matches the searcher
+func contentMatches(t *testing.T, searcher *Searcher, dir, name string) bool {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	info, err := os.Stat(path)
+	require.NoError(t, err, "Failed to stat %s", name)
+
+	return searcher.matches(path, info)
+}
+
+func TestMatchesContent(t *testing.T) {
+	tempDir := writeContentFiles(t, map[string][]byte{
+		"main.go":   []byte("package main\n\nfunc main() {\n}\n"),
+		"lib.go":    []byte("package lib\n\nfunc Helper() {}\n"),
+		"nolf.go":   []byte("package x; func main() {}"),
+		"empty.go":  {},
+		"binary.go": append([]byte("func main() {\x00"), make([]byte, 16)...),
+	})
+	require.NoError(t, os.Mkdir(filepath.Join(tempDir, "dir.go"), 0700), "Failed to create directory")
+
+	searcher, err := New(&Config{
+		Pattern: "*.go",
+		Content: "func main",
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"main.go", true},
+		{"lib.go", false},
+		{"nolf.go", true},
+		{"empty.go", false},
+		{"binary.go", false},
+		{"dir.go", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, contentMatches(t, searcher, tempDir, tt.name),
+				"Unexpected content match for %s", tt.name)
+		})
+	}
+}
+
+func TestMatchesContentBinary(t *testing.T) {
+	tempDir := writeContentFiles(t, map[string][]byte{
+		"blob.bin": []byte("\x00\x01\x02MAGIC\x03\x00"),
+	})
+
+	searcher, err := New(&Config{
+		Pattern: "*.bin",
+		Content: "MAGIC",
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+	assert.False(t, contentMatches(t, searcher, tempDir, "blob.bin"), "Expected binary file to be skipped")
+
+	searcher, err = New(&Config{
+		Pattern:       "*.bin",
+		Content:       "MAGIC",
+		ContentBinary: true,
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+	assert.True(t, contentMatches(t, searcher, tempDir, "blob.bin"), "Expected binary file to be searched")
+}
+
+func TestMatchesContentMaxSize(t *testing.T) {
+	large := strings.Repeat("filler line\n", 200) + "needle\n"
+	tempDir := writeContentFiles(t, map[string][]byte{
+		"small.txt": []byte("needle\n"),
+		"large.txt": []byte(large),
+	})
+
+	searcher, err := New(&Config{
+		Pattern:        "*.txt",
+		Content:        "needle",
+		ContentMaxSize: "1K",
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+
+	assert.True(t, contentMatches(t, searcher, tempDir, "small.txt"), "Expected small file to match")
+	assert.False(t, contentMatches(t, searcher, tempDir, "large.txt"), "Expected oversized file to be skipped")
+
+	searcher, err = New(&Config{
+		Pattern: "*.txt",
+		Content: "needle",
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+	assert.True(t, contentMatches(t, searcher, tempDir, "large.txt"), "Expected file under default cap to match")
+}
+
+func TestMatchesContentRegex(t *testing.T) {
+	tempDir := writeContentFiles(t, map[string][]byte{
+		"todo.go":  []byte("package a\n// TODO(alice): fix this\n"),
+		"plain.go": []byte("package b\n// todo without owner\n"),
+	})
+
+	searcher, err := New(&Config{
+		Pattern: `\.go$`,
+		Regex:   true,
+		Content: `TODO\(\w+\)`,
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+
+	assert.True(t, contentMatches(t, searcher, tempDir, "todo.go"), "Expected regex content to match")
+	assert.False(t, contentMatches(t, searcher, tempDir, "plain.go"), "Expected regex content not to match")
+}
+
+func TestNewWithInvalidContentOptions(t *testing.T) {
+	_, err := New(&Config{
+		Pattern:        "*",
+		Content:        "needle",
+		ContentMaxSize: "10X",
+	})
+	assert.Error(t, err, "Expected error for invalid content max size")
+
+	_, err = New(&Config{
+		Pattern: ".*",
+		Regex:   true,
+		Content: "needle(",
+	})
+	assert.Error(t, err, "Expected error for invalid content regex")
+}
+
+func TestMatchesContentSymlink(t *testing.T) {
+	tempDir := writeContentFiles(t, map[string][]byte{
+		"target.txt": []byte("needle\n"),
+	})
+	if err := os.Symlink("target.txt", filepath.Join(tempDir, "link.txt")); err != nil {
+		t.Skipf("Symlinks not supported: %v", err)
+	}
+
+	linkPath := filepath.Join(tempDir, "link.txt")
+	info, err := os.Lstat(linkPath)
+	require.NoError(t, err, "Failed to lstat link")
+
+	searcher, err := New(&Config{
+		Pattern: "*.txt",
+		Content: "needle",
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+	assert.False(t, searcher.matches(linkPath, info), "Expected link not to be searched without following links")
+
+	searcher, err = New(&Config{
+		Pattern:     "*.txt",
+		Content:     "needle",
+		FollowLinks: true,
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+	assert.True(t, searcher.matches(linkPath, info), "Expected linked file to be searched when following links")
+}
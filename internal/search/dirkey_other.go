@@ -0,0 +1,15 @@
+//go:build !unix
+
+package search
+
+import "os"
+
+// dirKey identifies a real directory by its resolved absolute path
+type dirKey struct {
+	path string
+}
+
+// newDirKey returns the identity of the directory at path
+func newDirKey(path string, _ os.FileInfo) dirKey {
+	return dirKey{path: resolvePath(path)}
+}
@@ -0,0 +1,24 @@
+//go:build unix
+
+package search
+
+import (
+	"os"
+	"syscall"
+)
+
+// dirKey identifies a real directory by device and inode number
+type dirKey struct {
+	dev  uint64
+	ino  uint64
+	path string
+}
+
+// newDirKey returns the identity of the directory described by info,
+// falling back to its resolved path when no inode is available
+func newDirKey(path string, info os.FileInfo) dirKey {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return dirKey{dev: uint64(stat.Dev), ino: stat.Ino} //nolint:unconvert // Dev type varies by platform
+	}
+	return dirKey{path: resolvePath(path)}
+}
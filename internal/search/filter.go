@@ -0,0 +1,205 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// compareOp describes how a filter value is compared against a file attribute
+type compareOp int
+
+const (
+	// opEqual matches when the attribute equals the filter value
+	opEqual compareOp = iota
+	// opGreaterEqual matches when the attribute is greater than or equal to the filter value
+	opGreaterEqual
+	// opLessEqual matches when the attribute is less than or equal to the filter value
+	opLessEqual
+)
+
+// Size unit multipliers
+const (
+	sizeKilobyte int64 = 1 << (10 * (iota + 1))
+	sizeMegabyte
+	sizeGigabyte
+	sizeTerabyte
+)
+
+// sizeUnits maps size suffixes to their byte multipliers
+var sizeUnits = map[byte]int64{
+	'B': 1,
+	'K': sizeKilobyte,
+	'M': sizeMegabyte,
+	'G': sizeGigabyte,
+	'T': sizeTerabyte,
+}
+
+// Duration unit multipliers
+const (
+	durationDay  = 24 * time.Hour
+	durationWeek = 7 * durationDay
+)
+
+// durationUnits maps duration suffixes to their multipliers
+var durationUnits = map[byte]time.Duration{
+	's': time.Second,
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': durationDay,
+	'w': durationWeek,
+}
+
+// sizeFilter holds a parsed --size expression
+type sizeFilter struct {
+	op    compareOp
+	bytes int64
+}
+
+// parseSize parses a size expression such as "+100M", "-1K" or "512".
+// A "+" prefix means greater than or equal, "-" means less than or equal,
+// and no prefix means exactly. It returns nil for an empty expression.
+func parseSize(expr string) (*sizeFilter, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	filter := &sizeFilter{op: opEqual}
+	value := expr
+
+	switch value[0] {
+	case '+':
+		filter.op = opGreaterEqual
+		value = value[1:]
+	case '-':
+		filter.op = opLessEqual
+		value = value[1:]
+	}
+
+	bytes, err := parseByteCount(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid size %q: %w", expr, err)
+	}
+
+	filter.bytes = bytes
+	return filter, nil
+}
+
+// parseByteCount parses an unsigned byte count with an optional
+// case-insensitive B, K, M, G or T suffix, such as "512" or "10M"
+func parseByteCount(value string) (int64, error) {
+	if value == "" {
+		return 0, fmt.Errorf("missing value")
+	}
+
+	multiplier := int64(1)
+	if unit, ok := sizeUnits[strings.ToUpper(value[len(value)-1:])[0]]; ok {
+		multiplier = unit
+		value = value[:len(value)-1]
+	}
+
+	number, err := parseCount(value)
+	if err != nil {
+		return 0, fmt.Errorf("expected <number> with an optional B, K, M, G or T suffix")
+	}
+
+	if number > math.MaxInt64/multiplier {
+		return 0, fmt.Errorf("value too large")
+	}
+
+	return number * multiplier, nil
+}
+
+// match reports whether size satisfies the filter
+func (f *sizeFilter) match(size int64) bool {
+	switch f.op {
+	case opGreaterEqual:
+		return size >= f.bytes
+	case opLessEqual:
+		return size <= f.bytes
+	default:
+		return size == f.bytes
+	}
+}
+
+// mtimeFilter holds a parsed --mtime expression resolved to an absolute cutoff
+type mtimeFilter struct {
+	op     compareOp
+	cutoff time.Time
+}
+
+// parseMtime parses a relative age expression such as "-7d" or "+1h" and
+// resolves it against now. A "-" prefix means modified within the given
+// duration, "+" means modified more than the given duration ago.
+// It returns nil for an empty expression.
+func parseMtime(expr string, now time.Time) (*mtimeFilter, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	const format = "expected +<n><unit> or -<n><unit> with a single unit of s, m, h, d or w " +
+		"(combined expressions such as -7d3h are not supported)"
+
+	filter := &mtimeFilter{}
+	switch expr[0] {
+	case '+':
+		// Older than the duration: modification time at or before the cutoff
+		filter.op = opLessEqual
+	case '-':
+		// Newer than the duration: modification time at or after the cutoff
+		filter.op = opGreaterEqual
+	default:
+		return nil, fmt.Errorf("invalid mtime %q: %s", expr, format)
+	}
+
+	value := expr[1:]
+	if len(value) < 2 {
+		return nil, fmt.Errorf("invalid mtime %q: %s", expr, format)
+	}
+
+	unit, ok := durationUnits[value[len(value)-1]]
+	if !ok {
+		return nil, fmt.Errorf("invalid mtime %q: %s", expr, format)
+	}
+
+	number, err := parseCount(value[:len(value)-1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid mtime %q: %s", expr, format)
+	}
+
+	if number > int64(math.MaxInt64/unit) {
+		return nil, fmt.Errorf("invalid mtime %q: value too large", expr)
+	}
+
+	filter.cutoff = now.Add(-time.Duration(number) * unit)
+	return filter, nil
+}
+
+// match reports whether modTime satisfies the filter
+func (f *mtimeFilter) match(modTime time.Time) bool {
+	if f.op == opLessEqual {
+		return !modTime.After(f.cutoff)
+	}
+	return !modTime.Before(f.cutoff)
+}
+
+// parseCount parses an unsigned decimal number made of digits only,
+// rejecting the sign prefixes strconv would otherwise accept
+func parseCount(value string) (int64, error) {
+	if value == "" {
+		return 0, fmt.Errorf("missing number")
+	}
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("invalid number %q", value)
+		}
+	}
+
+	number, err := strconv.ParseUint(value, 10, 63)
+	if err != nil {
+		return 0, err
+	}
+	return int64(number), nil
+}
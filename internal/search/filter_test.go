@@ -0,0 +1,229 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected *sizeFilter
+	}{
+		{"empty", "", nil},
+		{"plain bytes", "512", &sizeFilter{op: opEqual, bytes: 512}},
+		{"bytes suffix", "512B", &sizeFilter{op: opEqual, bytes: 512}},
+		{"greater kilobytes", "+1K", &sizeFilter{op: opGreaterEqual, bytes: 1 << 10}},
+		{"less megabytes", "-100M", &sizeFilter{op: opLessEqual, bytes: 100 << 20}},
+		{"gigabytes", "+2G", &sizeFilter{op: opGreaterEqual, bytes: 2 << 30}},
+		{"terabytes", "1T", &sizeFilter{op: opEqual, bytes: 1 << 40}},
+		{"lowercase unit", "-10k", &sizeFilter{op: opLessEqual, bytes: 10 << 10}},
+		{"lowercase bytes", "+7b", &sizeFilter{op: opGreaterEqual, bytes: 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseSize(tt.expr)
+			require.NoError(t, err, "Expected no error parsing %q", tt.expr)
+			assert.Equal(t, tt.expected, filter, "Parsed size filter should match expected")
+		})
+	}
+}
+
+func TestParseSizeInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"unknown unit", "10X"},
+		{"prefix only", "+"},
+		{"unit only", "M"},
+		{"double prefix", "+-10"},
+		{"repeated plus", "++10"},
+		{"minus then plus", "-+10"},
+		{"fractional", "1.5M"},
+		{"overflow", "99999999999T"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSize(tt.expr)
+			assert.Error(t, err, "Expected error parsing %q", tt.expr)
+		})
+	}
+}
+
+func TestSizeFilterMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		size     int64
+		expected bool
+	}{
+		{"greater equal above", "+1K", 2048, true},
+		{"greater equal boundary", "+1K", 1024, true},
+		{"greater equal below", "+1K", 1023, false},
+		{"less equal below", "-1K", 10, true},
+		{"less equal boundary", "-1K", 1024, true},
+		{"less equal above", "-1K", 1025, false},
+		{"exact match", "100", 100, true},
+		{"exact mismatch", "100", 101, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseSize(tt.expr)
+			require.NoError(t, err, "Expected no error parsing %q", tt.expr)
+			assert.Equal(t, tt.expected, filter.match(tt.size), "Size filter match should match expected")
+		})
+	}
+}
+
+func TestNewWithInvalidSize(t *testing.T) {
+	config := &Config{
+		Pattern: "*.log",
+		Size:    "10X",
+	}
+
+	_, err := New(config)
+	assert.Error(t, err, "Expected error for invalid size expression")
+}
+
+func TestMatchesSize(t *testing.T) {
+	tempDir := t.TempDir()
+
+	smallPath := filepath.Join(tempDir, "small.log")
+	largePath := filepath.Join(tempDir, "large.log")
+	dirPath := filepath.Join(tempDir, "dir.log")
+
+	require.NoError(t, os.WriteFile(smallPath, make([]byte, 100), 0600), "Failed to write small file")
+	require.NoError(t, os.WriteFile(largePath, make([]byte, 4096), 0600), "Failed to write large file")
+	require.NoError(t, os.Mkdir(dirPath, 0700), "Failed to create directory")
+
+	searcher, err := New(&Config{
+		Pattern: "*.log",
+		Size:    "+1K",
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+
+	for path, expected := range map[string]bool{
+		smallPath: false,
+		largePath: true,
+		dirPath:   true,
+	} {
+		info, err := os.Stat(path)
+		require.NoError(t, err, "Failed to stat %s", path)
+		assert.Equal(t, expected, searcher.matches(path, info), "Unexpected size match for %s", path)
+	}
+}
+
+func TestParseMtime(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected *mtimeFilter
+	}{
+		{"empty", "", nil},
+		{"within seconds", "-30s", &mtimeFilter{op: opGreaterEqual, cutoff: now.Add(-30 * time.Second)}},
+		{"within minutes", "-15m", &mtimeFilter{op: opGreaterEqual, cutoff: now.Add(-15 * time.Minute)}},
+		{"older than hours", "+1h", &mtimeFilter{op: opLessEqual, cutoff: now.Add(-time.Hour)}},
+		{"within days", "-7d", &mtimeFilter{op: opGreaterEqual, cutoff: now.Add(-7 * 24 * time.Hour)}},
+		{"older than weeks", "+2w", &mtimeFilter{op: opLessEqual, cutoff: now.Add(-14 * 24 * time.Hour)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseMtime(tt.expr, now)
+			require.NoError(t, err, "Expected no error parsing %q", tt.expr)
+			assert.Equal(t, tt.expected, filter, "Parsed mtime filter should match expected")
+		})
+	}
+}
+
+func TestParseMtimeInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"missing prefix", "7d"},
+		{"missing unit", "-7"},
+		{"unknown unit", "-7y"},
+		{"uppercase unit", "-7D"},
+		{"combined units", "-7d3h"},
+		{"prefix only", "-"},
+		{"unit only", "+d"},
+		{"minus then plus", "-+7d"},
+		{"repeated minus", "--7d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseMtime(tt.expr, time.Now())
+			assert.Error(t, err, "Expected error parsing %q", tt.expr)
+		})
+	}
+}
+
+func TestNewWithInvalidMtime(t *testing.T) {
+	config := &Config{
+		Pattern: "*",
+		Mtime:   "-7d3h",
+	}
+
+	_, err := New(config)
+	require.Error(t, err, "Expected error for combined mtime expression")
+	assert.Contains(t, err.Error(), "not supported", "Error should explain combined expressions")
+}
+
+func TestMatchesMtime(t *testing.T) {
+	tempDir := t.TempDir()
+	now := time.Now()
+
+	ages := map[string]time.Duration{
+		"fresh.txt":  time.Minute,
+		"recent.txt": 2 * time.Hour,
+		"old.txt":    10 * 24 * time.Hour,
+	}
+
+	for name, age := range ages {
+		path := filepath.Join(tempDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(name), 0600), "Failed to write %s", name)
+		modTime := now.Add(-age)
+		require.NoError(t, os.Chtimes(path, modTime, modTime), "Failed to backdate %s", name)
+	}
+
+	tests := []struct {
+		mtime    string
+		expected map[string]bool
+	}{
+		{"-7d", map[string]bool{"fresh.txt": true, "recent.txt": true, "old.txt": false}},
+		{"+1h", map[string]bool{"fresh.txt": false, "recent.txt": true, "old.txt": true}},
+		{"-10m", map[string]bool{"fresh.txt": true, "recent.txt": false, "old.txt": false}},
+		{"+1w", map[string]bool{"fresh.txt": false, "recent.txt": false, "old.txt": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mtime, func(t *testing.T) {
+			searcher, err := New(&Config{
+				Pattern: "*.txt",
+				Mtime:   tt.mtime,
+			})
+			require.NoError(t, err, "Expected no error creating searcher")
+
+			for name, expected := range tt.expected {
+				path := filepath.Join(tempDir, name)
+				info, err := os.Stat(path)
+				require.NoError(t, err, "Failed to stat %s", name)
+				assert.Equal(t, expected, searcher.matches(path, info), "Unexpected mtime match for %s", name)
+			}
+		})
+	}
+}
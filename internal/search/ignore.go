@@ -0,0 +1,240 @@
+package search
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ignoreFileName is the name of the per-directory ignore file
+const ignoreFileName = ".gitignore"
+
+// ignoreRule is a single compiled .gitignore pattern
+type ignoreRule struct {
+	regex    *regexp.Regexp
+	negate   bool
+	dirOnly  bool
+	basename bool
+}
+
+// ignoreStack holds the .gitignore rules of every directory below root.
+// Rules are keyed by the directory that declared them, so a nested
+// .gitignore adds to, and can override, the rules of its ancestors.
+type ignoreStack struct {
+	root  string
+	rules map[string][]ignoreRule
+}
+
+// newIgnoreStack creates an empty ignore stack for the tree at root
+func newIgnoreStack(root string) *ignoreStack {
+	return &ignoreStack{
+		root:  filepath.Clean(root),
+		rules: make(map[string][]ignoreRule),
+	}
+}
+
+// load reads the .gitignore in dir, if any, and registers its rules
+// for the directory reported as displayDir
+func (st *ignoreStack) load(dir, displayDir string) error {
+	file, err := os.Open(filepath.Join(dir, ignoreFileName)) // #nosec G304 -- path comes from the directory walk
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	rules, err := parseIgnoreRules(file)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) > 0 {
+		st.rules[filepath.Clean(displayDir)] = rules
+	}
+	return nil
+}
+
+// ignored reports whether path is ignored by the rules of its ancestors.
+// Rules are applied from the outermost directory inwards and the last
+// matching rule wins.
+func (st *ignoreStack) ignored(path string, isDir bool) bool {
+	rel, err := filepath.Rel(st.root, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	ignored := false
+	dir := st.root
+
+	for i := range parts {
+		if i > 0 {
+			dir = filepath.Join(dir, parts[i-1])
+		}
+
+		rules, ok := st.rules[dir]
+		if !ok {
+			continue
+		}
+
+		relPath := strings.Join(parts[i:], "/")
+		name := parts[len(parts)-1]
+		for _, rule := range rules {
+			if rule.match(relPath, name, isDir) {
+				ignored = !rule.negate
+			}
+		}
+	}
+
+	return ignored
+}
+
+// match reports whether the rule matches an entry at relPath (relative to
+// the directory declaring the rule) with the given base name
+func (r *ignoreRule) match(relPath, name string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	if r.basename {
+		return r.regex.MatchString(name)
+	}
+	return r.regex.MatchString(relPath)
+}
+
+// parseIgnoreRules parses .gitignore contents into compiled rules
+func parseIgnoreRules(r io.Reader) ([]ignoreRule, error) {
+	var rules []ignoreRule
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		rule, ok, err := parseIgnoreLine(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rules = append(rules, rule)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", ignoreFileName, err)
+	}
+	return rules, nil
+}
+
+// parseIgnoreLine compiles a single .gitignore line. It reports false for
+// blank lines and comments.
+func parseIgnoreLine(line string) (ignoreRule, bool, error) {
+	var rule ignoreRule
+
+	line = strings.TrimSuffix(line, "\r")
+	if !strings.HasSuffix(line, "\\ ") {
+		line = strings.TrimRight(line, " \t")
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return rule, false, nil
+	}
+
+	switch {
+	case strings.HasPrefix(line, "!"):
+		rule.negate = true
+		line = line[1:]
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return rule, false, nil
+	}
+
+	// Patterns without a slash match the name at any depth; a slash
+	// anywhere else anchors the pattern to the .gitignore directory
+	rule.basename = !strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	regex, err := regexp.Compile("^" + ignorePatternToRegex(line) + "$")
+	if err != nil {
+		return rule, false, fmt.Errorf("invalid %s pattern %q: %w", ignoreFileName, line, err)
+	}
+	rule.regex = regex
+
+	return rule, true, nil
+}
+
+// ignorePatternToRegex translates a .gitignore glob into a regular expression
+func ignorePatternToRegex(pattern string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		if expr, skip, ok := translateDoubleStar(pattern, i); ok {
+			sb.WriteString(expr)
+			i += skip
+			continue
+		}
+
+		switch c := pattern[i]; {
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '[':
+			expr, skip := translateCharClass(pattern, i)
+			sb.WriteString(expr)
+			i += skip
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return sb.String()
+}
+
+// translateDoubleStar translates a "**" at pattern[i] that spans whole path
+// components, returning the expression and the extra bytes consumed
+func translateDoubleStar(pattern string, i int) (expr string, skip int, ok bool) {
+	if !strings.HasPrefix(pattern[i:], "**") {
+		return "", 0, false
+	}
+
+	afterSlash := i > 0 && pattern[i-1] == '/'
+	switch {
+	case strings.HasPrefix(pattern[i:], "**/") && (i == 0 || afterSlash):
+		// Leading or middle "**/" matches zero or more directories
+		return "(?:.*/)?", 2, true
+	case afterSlash && i+2 == len(pattern):
+		// Trailing "/**" matches everything inside
+		return ".*", 1, true
+	default:
+		return "", 0, false
+	}
+}
+
+// translateCharClass translates the bracket expression starting at
+// pattern[i], returning the expression and the extra bytes consumed
+func translateCharClass(pattern string, i int) (expr string, skip int) {
+	end := strings.IndexByte(pattern[i+1:], ']')
+	if end < 0 {
+		return `\[`, 0
+	}
+
+	class := pattern[i+1 : i+1+end]
+	if strings.HasPrefix(class, "!") {
+		class = "^" + class[1:]
+	}
+	return "[" + strings.ReplaceAll(class, `\`, `\\`) + "]", end + 1
+}
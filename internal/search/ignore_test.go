@@ -0,0 +1,135 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIgnoreRules(t *testing.T) {
+	rules, err := parseIgnoreRules(strings.NewReader(`
+# comment
+*.log
+
+!keep.log
+/dist
+build/
+\#hash
+`))
+	require.NoError(t, err, "Expected no error parsing rules")
+	require.Len(t, rules, 5, "Expected comments and blank lines to be skipped")
+
+	assert.True(t, rules[0].basename, "Expected slashless pattern to match basenames")
+	assert.True(t, rules[1].negate, "Expected negated pattern")
+	assert.False(t, rules[2].basename, "Expected leading slash to anchor pattern")
+	assert.True(t, rules[3].dirOnly, "Expected trailing slash to match directories only")
+	assert.True(t, rules[4].regex.MatchString("#hash"), "Expected escaped hash to be literal")
+}
+
+func TestIgnoreRuleMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		{"basename glob", "*.log", "logs/app.log", false, true},
+		{"basename glob no match", "*.log", "logs/app.txt", false, false},
+		{"star stays in component", "a*b", "ax/yb", false, false},
+		{"question mark", "file?.txt", "file1.txt", false, true},
+		{"char class", "file[0-9].txt", "file7.txt", false, true},
+		{"negated char class", "file[!0-9].txt", "file7.txt", false, false},
+		{"anchored", "/dist", "dist", true, true},
+		{"anchored nested no match", "/dist", "web/dist", true, false},
+		{"middle slash anchors", "doc/*.md", "doc/a.md", false, true},
+		{"middle slash nested no match", "doc/*.md", "x/doc/a.md", false, false},
+		{"dir only matches dir", "build/", "build", true, true},
+		{"dir only skips file", "build/", "build", false, false},
+		{"leading double star", "**/cache", "a/b/cache", true, true},
+		{"leading double star top level", "**/cache", "cache", true, true},
+		{"middle double star", "a/**/z", "a/b/c/z", false, true},
+		{"middle double star zero dirs", "a/**/z", "a/z", false, true},
+		{"trailing double star", "out/**", "out/x/y.bin", false, true},
+		{"trailing double star not dir itself", "out/**", "out", true, false},
+		{"escaped wildcard", `\*.txt`, "*.txt", false, true},
+		{"escaped wildcard literal", `\*.txt`, "a.txt", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok, err := parseIgnoreLine(tt.pattern)
+			require.NoError(t, err, "Expected no error parsing %q", tt.pattern)
+			require.True(t, ok, "Expected %q to produce a rule", tt.pattern)
+
+			name := tt.path[strings.LastIndex(tt.path, "/")+1:]
+			assert.Equal(t, tt.expected, rule.match(tt.path, name, tt.isDir), "Rule match should match expected")
+		})
+	}
+}
+
+func TestSearchUseGitignore(t *testing.T) {
+	root := t.TempDir()
+
+	files := map[string]string{
+		".gitignore":            "*.log\n!important.log\n/dist\nbuild/\n",
+		"app.log":               "",
+		"important.log":         "",
+		"main.go":               "",
+		"dist/bundle.js":        "",
+		"web/dist/page.js":      "",
+		"web/build/out.js":      "",
+		"docs/build":            "",
+		"sub/.gitignore":        "!debug.log\n/local.txt\nimportant.log\n",
+		"sub/debug.log":         "",
+		"sub/trace.log":         "",
+		"sub/important.log":     "",
+		"sub/local.txt":         "",
+		"sub/deep/local.txt":    "",
+		"sub/deep/trace.log":    "",
+		"sub/deep/keep/file.go": "",
+	}
+	for name, data := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700), "Failed to create directory for %s", name)
+		require.NoError(t, os.WriteFile(path, []byte(data), 0600), "Failed to write %s", name)
+	}
+
+	searcher, err := New(&Config{
+		Pattern:      "*",
+		Include:      []string{root},
+		UseGitignore: true,
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+
+	results, err := searcher.Search()
+	require.NoError(t, err, "Expected no error searching")
+
+	var found []string
+	for _, path := range resultPaths(t, root, results) {
+		if !strings.HasSuffix(path, ".gitignore") {
+			found = append(found, path)
+		}
+	}
+
+	assert.ElementsMatch(t, []string{
+		".",
+		"important.log",
+		"main.go",
+		"web",
+		"web/dist",
+		"web/dist/page.js",
+		"docs",
+		"docs/build",
+		"sub",
+		"sub/debug.log",
+		"sub/deep",
+		"sub/deep/local.txt",
+		"sub/deep/keep",
+		"sub/deep/keep/file.go",
+	}, found, "Expected .gitignore rules to be applied")
+}
@@ -2,12 +2,16 @@
 package search
 
 import (
+	"context"
 	"fmt"
 	"os"
+	pathpkg "path"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -19,19 +23,26 @@ const (
 
 // Config holds search configuration
 type Config struct {
-	Pattern     string
-	Advanced    bool
-	Extensions  []string
-	Size        string
-	Mtime       string
-	Content     string
-	Exclude     []string
-	Include     []string
-	Threads     int
-	Depth       int
-	FollowLinks bool
-	MaxResults  int
-	Verbose     bool
+	Pattern        string
+	Advanced       bool
+	Regex          bool
+	RegexFullPath  bool
+	Extensions     []string
+	Size           string
+	Mtime          string
+	Content        string
+	ContentBinary  bool
+	ContentMaxSize string
+	Exclude        []string
+	Include        []string
+	Threads        int
+	Depth          int
+	FollowLinks    bool
+	UseGitignore   bool
+	MaxResults     int
+	Sort           string
+	Reverse        bool
+	Verbose        bool
 }
 
 // Result represents a search result
@@ -45,20 +56,26 @@ type Result struct {
 
 // Searcher performs file searches
 type Searcher struct {
-	config  *Config
-	results chan *Result
-	done    chan struct{}
-	wg      sync.WaitGroup
+	config      *Config
+	regex       *regexp.Regexp
+	sizeFilter  *sizeFilter
+	mtimeFilter *mtimeFilter
+	content     *contentMatcher
+	less        resultLess
+	results     chan *Result
+	done        chan struct{}
+	stopOnce    sync.Once
+	emitted     atomic.Int64
+	visited     atomic.Int64
+	seenDirs    map[dirKey]struct{}
+	seenMu      sync.Mutex
+	wg          sync.WaitGroup
 }
 
 // New creates a new searcher instance
 func New(config *Config) (*Searcher, error) {
-	if config == nil {
-		return nil, fmt.Errorf("config cannot be nil")
-	}
-
-	if config.Pattern == "" {
-		return nil, fmt.Errorf("search pattern cannot be empty")
+	if err := validateConfig(config); err != nil {
+		return nil, err
 	}
 
 	// Set default thread count
@@ -66,27 +83,107 @@ func New(config *Config) (*Searcher, error) {
 		config.Threads = runtime.NumCPU()
 	}
 
-	return &Searcher{
+	s := &Searcher{
 		config:  config,
 		results: make(chan *Result, defaultResultsBufferSize),
 		done:    make(chan struct{}),
-	}, nil
+	}
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// validateConfig rejects missing or conflicting search options
+func validateConfig(config *Config) error {
+	if config == nil {
+		return fmt.Errorf("config cannot be nil")
+	}
+
+	if config.Pattern == "" {
+		return fmt.Errorf("search pattern cannot be empty")
+	}
+
+	if config.Regex && config.Advanced {
+		return fmt.Errorf("regex and advanced search modes are mutually exclusive")
+	}
+
+	if config.RegexFullPath && !config.Regex {
+		return fmt.Errorf("regex full path matching requires regex search mode")
+	}
+
+	if config.Reverse && config.Sort == "" {
+		return fmt.Errorf("reverse ordering requires a sort key")
+	}
+
+	return nil
+}
+
+// compile parses the pattern, filters and sort order once so they are
+// not re-evaluated for every file
+func (s *Searcher) compile() error {
+	var err error
+
+	if s.config.Regex {
+		s.regex, err = regexp.Compile(s.config.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid regex pattern %q: %w", s.config.Pattern, err)
+		}
+	}
+
+	if s.sizeFilter, err = parseSize(s.config.Size); err != nil {
+		return err
+	}
+
+	if s.mtimeFilter, err = parseMtime(s.config.Mtime, time.Now()); err != nil {
+		return err
+	}
+
+	if s.content, err = newContentMatcher(s.config); err != nil {
+		return err
+	}
+
+	if s.config.Sort != "" {
+		if s.less, err = newResultLess(s.config.Sort, s.config.Reverse); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Search performs the file search
 func (s *Searcher) Search() ([]*Result, error) {
+	return s.SearchContext(context.Background())
+}
+
+// SearchContext performs the file search until the tree is exhausted,
+// MaxResults entries have been found or ctx is canceled. On cancellation
+// the results collected so far are returned along with the context error.
+// When a sort key is set the whole tree is searched and the first
+// MaxResults entries of the sorted order are returned.
+func (s *Searcher) SearchContext(ctx context.Context) ([]*Result, error) {
 	var results []*Result
-	var mu sync.Mutex
+	collected := make(chan struct{})
 
 	// Start result collector
 	go func() {
-		for result := range s.results {
-			mu.Lock()
-			if len(results) < s.config.MaxResults {
+		defer close(collected)
+
+		if s.less == nil || s.config.MaxResults <= 0 {
+			for result := range s.results {
 				results = append(results, result)
 			}
-			mu.Unlock()
+			return
 		}
+
+		// Keep only the best MaxResults entries while sorting
+		top := &topResults{less: s.less, limit: s.config.MaxResults}
+		for result := range s.results {
+			top.add(result)
+		}
+		results = top.items
 	}()
 
 	// Determine search roots
@@ -95,14 +192,54 @@ func (s *Searcher) Search() ([]*Result, error) {
 	// Start search workers
 	for _, root := range searchRoots {
 		s.wg.Add(1)
-		go s.searchWorker(root)
+		go s.searchWorker(ctx, root)
 	}
 
-	// Wait for all workers to complete
+	// Wait for all workers to complete and the collector to drain
 	s.wg.Wait()
+	s.stop()
 	close(s.results)
+	<-collected
+
+	if s.less != nil {
+		sortResults(results, s.less)
+	}
+
+	return results, ctx.Err()
+}
+
+// stop signals all workers to terminate
+func (s *Searcher) stop() {
+	s.stopOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+// stopped reports whether the search should terminate
+func (s *Searcher) stopped(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// reserveResult claims a slot for a new result, stopping the search once
+// MaxResults slots have been claimed. A MaxResults of 0 means unlimited.
+// Sorted searches never stop early since any later entry may sort first.
+func (s *Searcher) reserveResult() bool {
+	if s.config.MaxResults <= 0 || s.less != nil {
+		return true
+	}
 
-	return results, nil
+	n := s.emitted.Add(1)
+	if n >= int64(s.config.MaxResults) {
+		s.stop()
+	}
+	return n <= int64(s.config.MaxResults)
 }
 
 // getSearchRoots returns the directories to search
@@ -125,131 +262,347 @@ func (s *Searcher) getSearchRoots() []string {
 	return roots
 }
 
+// walkState holds the per-root state shared by a worker and the walks
+// of the symlinks it follows
+type walkState struct {
+	root       string
+	skipSystem bool
+	ignores    *ignoreStack
+}
+
 // searchWorker performs search in a specific directory tree
-func (s *Searcher) searchWorker(root string) {
+func (s *Searcher) searchWorker(ctx context.Context, root string) {
 	defer s.wg.Done()
 
-	// Add support for parallel search
-	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+	state := &walkState{
+		root: root,
+		// System directories are only skipped when the root is not inside one
+		skipSystem: !isSystemPath(root),
+	}
+	if s.config.UseGitignore {
+		state.ignores = newIgnoreStack(root)
+	}
+
+	s.walk(ctx, state, root, root)
+}
+
+// walkEntry is a single entry reported by the directory walk
+type walkEntry struct {
+	// path is the real filesystem path
+	path string
+	// displayPath is the path reported to the user, which goes through any followed links
+	displayPath string
+	info        os.FileInfo
+}
+
+// walk searches the directory tree at dir, reporting entries under displayDir.
+// The two differ when descending into the target of a followed symlink.
+func (s *Searcher) walk(ctx context.Context, state *walkState, dir, displayDir string) {
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if s.stopped(ctx) {
+			return filepath.SkipAll
+		}
+		s.visited.Add(1)
+
+		entry := &walkEntry{path: path, displayPath: path, info: info}
+		if dir != displayDir {
+			entry.displayPath = filepath.Join(displayDir, strings.TrimPrefix(path, dir))
+		}
+
 		if err != nil {
 			// Skip directories we can't access
 			if s.config.Verbose {
-				fmt.Fprintf(os.Stderr, "Warning: Cannot access %s: %v\n", path, err)
+				fmt.Fprintf(os.Stderr, "Warning: Cannot access %s: %v\n", entry.displayPath, err)
 			}
 			return nil
 		}
 
-		// Check if we should exclude this path (e.g. /proc, /sys, /dev, /tmp)
-		if s.shouldExclude(path) {
+		if s.skipEntry(state, entry) {
 			if info.IsDir() {
 				return filepath.SkipDir
 			}
 			return nil
 		}
 
-		// Check depth limit
-		if s.config.Depth > 0 {
-			depth := strings.Count(strings.TrimPrefix(path, root), string(os.PathSeparator))
-			if depth > s.config.Depth {
-				if info.IsDir() {
-					return filepath.SkipDir
-				}
-				return nil
-			}
-		}
+		// The target of a followed link has already been reported as the link itself
+		return s.visitEntry(ctx, state, entry, dir != displayDir && path == dir)
+	})
 
-		// Check if this matches our search criteria
-		if s.matches(path, info) {
-			result := &Result{
-				Path:    path,
-				Size:    info.Size(),
-				ModTime: info.ModTime(),
-				IsDir:   info.IsDir(),
-				Mode:    info.Mode().String(),
-			}
+	if err != nil && s.config.Verbose {
+		fmt.Fprintf(os.Stderr, "Warning: Error walking %s: %v\n", displayDir, err)
+	}
+}
 
-			select {
-			case s.results <- result:
-			case <-s.done:
-				return fmt.Errorf("search canceled")
-			}
+// skipEntry reports whether an entry (and, for directories, everything
+// below it) should be left out of the search
+func (s *Searcher) skipEntry(state *walkState, entry *walkEntry) bool {
+	return s.isExcluded(state, entry) || s.exceedsDepth(state, entry) || s.alreadyVisited(entry)
+}
+
+// isExcluded checks the user exclusions, system directories and .gitignore rules
+func (s *Searcher) isExcluded(state *walkState, entry *walkEntry) bool {
+	// Check if we should exclude this path (e.g. /proc, /sys, /dev, /tmp)
+	if s.isUserExcluded(entry.displayPath) || (state.skipSystem && isSystemPath(entry.path)) {
+		return true
+	}
+
+	// Check .gitignore rules declared by ancestor directories
+	return state.ignores != nil && state.ignores.ignored(entry.displayPath, entry.info.IsDir())
+}
+
+// exceedsDepth checks the entry against the depth limit
+func (s *Searcher) exceedsDepth(state *walkState, entry *walkEntry) bool {
+	if s.config.Depth <= 0 {
+		return false
+	}
+
+	depth := strings.Count(strings.TrimPrefix(entry.displayPath, state.root), string(os.PathSeparator))
+	return depth > s.config.Depth
+}
+
+// alreadyVisited reports a real directory that has been entered before.
+// Never entering the same directory twice when following links breaks loops,
+// and reports a directory reached through a link that sorts before it only
+// under that link's path.
+func (s *Searcher) alreadyVisited(entry *walkEntry) bool {
+	if !s.config.FollowLinks || !entry.info.IsDir() || s.enterDir(entry.path, entry.info) {
+		return false
+	}
+
+	if s.config.Verbose {
+		fmt.Fprintf(os.Stderr, "Warning: Skipping %s: directory already visited via another path\n", entry.displayPath)
+	}
+	return true
+}
+
+// visitEntry loads ignore rules, emits the entry and follows it if it is a link.
+// linkTarget marks the root of a followed link, which is not emitted again.
+func (s *Searcher) visitEntry(ctx context.Context, state *walkState, entry *walkEntry, linkTarget bool) error {
+	// Register this directory's .gitignore rules for its descendants
+	if state.ignores != nil && entry.info.IsDir() {
+		if err := state.ignores.load(entry.path, entry.displayPath); err != nil && s.config.Verbose {
+			fmt.Fprintf(os.Stderr, "Warning: Cannot read %s in %s: %v\n", ignoreFileName, entry.displayPath, err)
+		}
+	}
+
+	if !linkTarget {
+		if err := s.emit(ctx, entry.displayPath, entry.info); err != nil {
+			return err
 		}
+	}
+
+	if s.config.FollowLinks && entry.info.Mode()&os.ModeSymlink != 0 {
+		s.followLink(ctx, state, entry.path, entry.displayPath)
+	}
+
+	return nil
+}
 
+// emit sends path to the result collector if it matches the search criteria.
+// It returns filepath.SkipAll once the search should stop.
+func (s *Searcher) emit(ctx context.Context, path string, info os.FileInfo) error {
+	if !s.matches(path, info) {
 		return nil
-	})
+	}
 
-	if err != nil && s.config.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Error walking %s: %v\n", root, err)
+	if !s.reserveResult() {
+		return filepath.SkipAll
+	}
+
+	result := &Result{
+		Path:    path,
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+		IsDir:   info.IsDir(),
+		Mode:    info.Mode().String(),
+	}
+
+	select {
+	case s.results <- result:
+		return nil
+	case <-ctx.Done():
+		return filepath.SkipAll
 	}
 }
 
+// followLink descends into the directory a symlink points to, if any
+func (s *Searcher) followLink(ctx context.Context, state *walkState, path, displayPath string) {
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		// Dangling links have nothing to descend into
+		return
+	}
+
+	info, err := os.Stat(target)
+	if err != nil || !info.IsDir() {
+		return
+	}
+
+	if state.skipSystem && isSystemPath(target) {
+		return
+	}
+
+	s.walk(ctx, state, target, displayPath)
+}
+
+// enterDir records a real directory as visited, returning false if it
+// has been visited before
+func (s *Searcher) enterDir(path string, info os.FileInfo) bool {
+	key := newDirKey(path, info)
+
+	s.seenMu.Lock()
+	defer s.seenMu.Unlock()
+
+	if s.seenDirs == nil {
+		s.seenDirs = make(map[dirKey]struct{})
+	}
+	if _, seen := s.seenDirs[key]; seen {
+		return false
+	}
+	s.seenDirs[key] = struct{}{}
+	return true
+}
+
+// resolvePath returns the absolute path of path with all symlinks resolved
+func resolvePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return path
+}
+
 // shouldExclude checks if a path should be excluded
 func (s *Searcher) shouldExclude(path string) bool {
+	return s.isUserExcluded(path) || isSystemPath(path)
+}
+
+// isUserExcluded checks if a path matches the configured exclusions.
+// Absolute exclusions match the directory and everything below it, relative
+// ones match a run of whole path components anywhere in the path.
+func (s *Searcher) isUserExcluded(path string) bool {
 	for _, exclude := range s.config.Exclude {
-		if strings.Contains(path, exclude) {
+		if exclude == "" {
+			continue
+		}
+		if isAbsPath(exclude) {
+			if hasPathPrefix(path, exclude) {
+				return true
+			}
+		} else if containsPathComponents(path, exclude) {
 			return true
 		}
 	}
+	return false
+}
 
-	// Default exclusions for system directories
+// isSystemPath checks if a path is inside a default excluded system directory
+func isSystemPath(path string) bool {
 	systemDirs := []string{"/proc", "/sys", "/dev", "/tmp"}
 	for _, sysDir := range systemDirs {
-		if strings.HasPrefix(path, sysDir) {
+		if hasPathPrefix(path, sysDir) {
 			return true
 		}
 	}
-
 	return false
 }
 
+// slashPath returns a cleaned, /-separated form of p so paths can be
+// compared the same way regardless of the platform separator
+func slashPath(p string) string {
+	return pathpkg.Clean(filepath.ToSlash(p))
+}
+
+// isAbsPath checks if p is absolute, treating a leading / as absolute on every platform
+func isAbsPath(p string) bool {
+	return filepath.IsAbs(p) || strings.HasPrefix(filepath.ToSlash(p), "/")
+}
+
+// hasPathPrefix checks if path is dir or lies below it
+func hasPathPrefix(path, dir string) bool {
+	path, dir = slashPath(path), slashPath(dir)
+	if dir == "/" {
+		// The filesystem root contains every absolute path
+		return isAbsPath(path)
+	}
+	return path == dir || strings.HasPrefix(path, dir+"/")
+}
+
+// containsPathComponents checks if the components of sub appear
+// consecutively among the components of path
+func containsPathComponents(path, sub string) bool {
+	path = strings.Trim(slashPath(path), "/")
+	sub = strings.Trim(slashPath(sub), "/")
+	return strings.Contains("/"+path+"/", "/"+sub+"/")
+}
+
 // matches checks if a file matches the search criteria
-func (s *Searcher) matches(path string, _ os.FileInfo) bool {
+func (s *Searcher) matches(path string, info os.FileInfo) bool {
 	filename := filepath.Base(path)
 
-	// Pattern matching
+	return s.matchesName(path, filename) && s.matchesAttributes(path, filename, info)
+}
+
+// matchesName checks the filename (or full path) against the search pattern
+func (s *Searcher) matchesName(path, filename string) bool {
+	if s.regex != nil {
+		// Regular expression matching against the filename or full path
+		target := filename
+		if s.config.RegexFullPath {
+			target = path
+		}
+		return s.regex.MatchString(target)
+	}
+
 	if s.config.Advanced {
 		// Fuzzy matching - check if pattern characters appear in order
-		if !s.fuzzyMatch(strings.ToLower(filename), strings.ToLower(s.config.Pattern)) {
-			return false
-		}
-	} else {
-		// Exact or wildcard matching
-		matched, err := filepath.Match(s.config.Pattern, filename)
-		if err != nil || !matched {
-			// Try case-insensitive match
-			matched, _ = filepath.Match(strings.ToLower(s.config.Pattern), strings.ToLower(filename))
-			if !matched {
-				return false
-			}
-		}
+		return s.fuzzyMatch(strings.ToLower(filename), strings.ToLower(s.config.Pattern))
 	}
 
-	// Extension filtering
-	if len(s.config.Extensions) > 0 {
-		ext := strings.TrimPrefix(filepath.Ext(filename), ".")
-		found := false
-		for _, allowedExt := range s.config.Extensions {
-			if strings.EqualFold(ext, allowedExt) {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
+	// Exact or wildcard matching
+	matched, err := filepath.Match(s.config.Pattern, filename)
+	if err != nil || !matched {
+		// Try case-insensitive match
+		matched, _ = filepath.Match(strings.ToLower(s.config.Pattern), strings.ToLower(filename))
 	}
+	return matched
+}
 
-	// Size filtering (basic implementation)
-	// TODO: Implement size filtering
-	// For now, we accept all files regardless of size
-	_ = s.config.Size
+// matchesAttributes checks the extension, size, modification time and content filters
+func (s *Searcher) matchesAttributes(path, filename string, info os.FileInfo) bool {
+	if !s.matchesExtension(filename) {
+		return false
+	}
 
-	// Modification time filtering (basic implementation)
-	// TODO: Implement mtime filtering
-	// For now, we accept all files regardless of modification time
-	_ = s.config.Mtime
+	// Size filtering (directories are exempt since their size is not meaningful)
+	if s.sizeFilter != nil && info != nil && !info.IsDir() && !s.sizeFilter.match(info.Size()) {
+		return false
+	}
 
-	return true
+	// Modification time filtering
+	if s.mtimeFilter != nil && info != nil && !s.mtimeFilter.match(info.ModTime()) {
+		return false
+	}
+
+	// Content filtering runs last since it has to read the file
+	return s.content == nil || s.content.match(path, info)
+}
+
+// matchesExtension checks the filename against the extension filter
+func (s *Searcher) matchesExtension(filename string) bool {
+	if len(s.config.Extensions) == 0 {
+		return true
+	}
+
+	ext := strings.TrimPrefix(filepath.Ext(filename), ".")
+	for _, allowedExt := range s.config.Extensions {
+		if strings.EqualFold(ext, allowedExt) {
+			return true
+		}
+	}
+	return false
 }
 
 // fuzzyMatch performs fuzzy string matching
@@ -288,3 +288,60 @@ func BenchmarkExtensionFiltering(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkRegexMatching tests regular expression matching scenarios
+func BenchmarkRegexMatching(b *testing.B) {
+	patterns := []struct {
+		name     string
+		pattern  string
+		fullPath bool
+		files    []string
+	}{
+		{
+			name:    "Suffix",
+			pattern: `\.go$`,
+			files:   []string{"main.go", "config.go", "test.py", "doc.md"},
+		},
+		{
+			name:    "Anchored",
+			pattern: `^main\.go$`,
+			files:   []string{"main.go", "main.py", "test.go", "config.go"},
+		},
+		{
+			name:    "CaseInsensitive",
+			pattern: `(?i)^test_.*\.go$`,
+			files:   []string{"TEST_main.go", "test_config.go", "main_test.go", "test.py"},
+		},
+		{
+			name:     "FullPath",
+			pattern:  `/internal/.*_test\.go$`,
+			fullPath: true,
+			files: []string{
+				"/repo/internal/search/search_test.go",
+				"/repo/internal/output/output.go",
+				"/repo/cmd/glocate/main.go",
+				"/repo/internal/version/version_test.go",
+			},
+		},
+	}
+
+	for _, tt := range patterns {
+		b.Run(tt.name, func(b *testing.B) {
+			searcher, err := New(&Config{
+				Pattern:       tt.pattern,
+				Regex:         true,
+				RegexFullPath: tt.fullPath,
+			})
+			if err != nil {
+				b.Fatalf("Failed to create searcher for pattern %s: %v", tt.pattern, err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, file := range tt.files {
+					searcher.matches(file, nil)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package search
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -38,7 +40,7 @@ func TestNewWithEmptyPattern(t *testing.T) {
 func TestShouldExclude(t *testing.T) {
 	searcher := &Searcher{
 		config: &Config{
-			Exclude: []string{"node_modules", ".git"},
+			Exclude: []string{"node_modules", ".git", "build", "/opt/vendor", "web/dist"},
 		},
 	}
 
@@ -52,8 +54,17 @@ func TestShouldExclude(t *testing.T) {
 		{"sys filesystem", "/sys/devices", true},
 		{"dev filesystem", "/dev/null", true},
 		{"tmp directory", "/tmp/test", true},
+		{"system dir prefix only", "/processes/list", false},
 		{"node_modules", "/home/user/node_modules/package", true},
 		{"git directory", "/home/user/.git/config", true},
+		{"git prefix only", "/home/user/.github/workflows", false},
+		{"component directory", "/home/user/build/main.go", true},
+		{"component substring", "/home/user/rebuild/main.go", false},
+		{"component at end", "/home/user/build", true},
+		{"absolute exclusion", "/opt/vendor/lib", true},
+		{"absolute exclusion elsewhere", "/home/opt/vendor/lib", false},
+		{"multi component", "/srv/web/dist/app.js", true},
+		{"multi component partial", "/srv/web/distro/app.js", false},
 	}
 
 	for _, tt := range tests {
@@ -158,3 +169,248 @@ func TestGetSearchRoots(t *testing.T) {
 	roots = searcher.getSearchRoots()
 	assert.NotEmpty(t, roots, "Expected default search roots")
 }
+
+func TestNewWithRegex(t *testing.T) {
+	searcher, err := New(&Config{
+		Pattern: `^main\.go$`,
+		Regex:   true,
+	})
+	require.NoError(t, err, "Expected no error creating regex searcher")
+	require.NotNil(t, searcher.regex, "Expected regex to be compiled")
+
+	_, err = New(&Config{
+		Pattern: `main(`,
+		Regex:   true,
+	})
+	assert.Error(t, err, "Expected error for invalid regex")
+
+	_, err = New(&Config{
+		Pattern:  `main`,
+		Regex:    true,
+		Advanced: true,
+	})
+	assert.Error(t, err, "Expected error when combining regex and advanced modes")
+
+	_, err = New(&Config{
+		Pattern:       `main`,
+		RegexFullPath: true,
+	})
+	assert.Error(t, err, "Expected error for full path matching without regex mode")
+}
+
+func TestMatchesRegex(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		fullPath bool
+		path     string
+		expected bool
+	}{
+		{"anchored match", `^main\.go$`, false, "/src/main.go", true},
+		{"anchored rejects prefix", `^main\.go$`, false, "/src/xmain.go", false},
+		{"anchored rejects suffix", `^main\.go$`, false, "/src/main.go.bak", false},
+		{"unanchored substring", `_test\.go`, false, "/src/search_test.go", true},
+		{"case sensitive by default", `^readme\.md$`, false, "/src/README.md", false},
+		{"case insensitive flag", `(?i)^readme\.md$`, false, "/src/README.md", true},
+		{"basename ignores directories", `^src/`, false, "src/main.go", false},
+		{"full path match", `/internal/.*\.go$`, true, "/repo/internal/search.go", true},
+		{"full path no match", `/internal/.*\.go$`, true, "/repo/cmd/main.go", false},
+		{"full path anchored", `^/repo/cmd/`, true, "/repo/cmd/main.go", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searcher, err := New(&Config{
+				Pattern:       tt.pattern,
+				Regex:         true,
+				RegexFullPath: tt.fullPath,
+			})
+			require.NoError(t, err, "Expected no error creating regex searcher")
+			assert.Equal(t, tt.expected, searcher.matches(tt.path, nil), "Regex match result should match expected")
+		})
+	}
+}
+
+// createSearchTree creates dirs directories each holding files .txt files
+func createSearchTree(t *testing.T, dirs, files int) string {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	for i := 0; i < dirs; i++ {
+		dir := filepath.Join(tempDir, fmt.Sprintf("dir%d", i))
+		require.NoError(t, os.MkdirAll(dir, 0700), "Failed to create directory %s", dir)
+
+		for j := 0; j < files; j++ {
+			file := filepath.Join(dir, fmt.Sprintf("file%d.txt", j))
+			require.NoError(t, os.WriteFile(file, nil, 0600), "Failed to write file %s", file)
+		}
+	}
+
+	return tempDir
+}
+
+func TestSearch(t *testing.T) {
+	tempDir := createSearchTree(t, 5, 4)
+
+	searcher, err := New(&Config{
+		Pattern: "*.txt",
+		Include: []string{tempDir},
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+
+	results, err := searcher.Search()
+	require.NoError(t, err, "Expected no error searching")
+	assert.Len(t, results, 20, "Expected every file to be found")
+}
+
+func TestSearchStopsAtMaxResults(t *testing.T) {
+	const dirs, files = 50, 20
+	tempDir := createSearchTree(t, dirs, files)
+
+	searcher, err := New(&Config{
+		Pattern:    "*.txt",
+		Include:    []string{tempDir},
+		MaxResults: 5,
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+
+	results, err := searcher.Search()
+	require.NoError(t, err, "Expected no error searching")
+	assert.Len(t, results, 5, "Expected results to be capped")
+
+	total := int64(1 + dirs + dirs*files)
+	assert.Less(t, searcher.visited.Load(), total/10, "Expected walk to terminate early")
+
+	select {
+	case <-searcher.done:
+	default:
+		t.Error("Expected done channel to be closed")
+	}
+}
+
+func TestSearchContextCanceled(t *testing.T) {
+	tempDir := createSearchTree(t, 10, 10)
+
+	searcher, err := New(&Config{
+		Pattern: "*.txt",
+		Include: []string{tempDir},
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := searcher.SearchContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled, "Expected context cancellation error")
+	assert.Empty(t, results, "Expected no results after cancellation")
+	assert.Zero(t, searcher.visited.Load(), "Expected walk not to visit any entries")
+}
+
+// createSymlinkTree creates a tree containing a symlink loop, a link to a
+// sibling directory and a link to a directory outside the search root
+func createSymlinkTree(t *testing.T) string {
+	t.Helper()
+
+	base := t.TempDir()
+	root := filepath.Join(base, "root")
+	outside := filepath.Join(base, "outside")
+
+	for _, dir := range []string{"a", "b", "c"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0700), "Failed to create directory %s", dir)
+	}
+	require.NoError(t, os.MkdirAll(outside, 0700), "Failed to create outside directory")
+
+	for _, file := range []string{"a/a.txt", "b/b.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(root, file), nil, 0600), "Failed to write %s", file)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "x.txt"), nil, 0600), "Failed to write outside file")
+
+	links := map[string]string{
+		"a/loop": "..",
+		"b/toA":  "../a",
+		"c/out":  outside,
+	}
+	for link, target := range links {
+		if err := os.Symlink(target, filepath.Join(root, link)); err != nil {
+			t.Skipf("Symlinks not supported: %v", err)
+		}
+	}
+
+	return root
+}
+
+// resultPaths returns the paths of results relative to root
+func resultPaths(t *testing.T, root string, results []*Result) []string {
+	t.Helper()
+
+	paths := make([]string, 0, len(results))
+	for _, result := range results {
+		rel, err := filepath.Rel(root, result.Path)
+		require.NoError(t, err, "Failed to relativize %s", result.Path)
+		paths = append(paths, filepath.ToSlash(rel))
+	}
+
+	return paths
+}
+
+func TestSearchFollowLinks(t *testing.T) {
+	root := createSymlinkTree(t)
+
+	searcher, err := New(&Config{
+		Pattern:     "*.txt",
+		Include:     []string{root},
+		FollowLinks: true,
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+
+	results, err := searcher.Search()
+	require.NoError(t, err, "Expected no error searching")
+
+	// Each real directory is walked exactly once: the loop back to root and
+	// the link to the already visited sibling are both skipped
+	assert.ElementsMatch(t, []string{"a/a.txt", "b/b.txt", "c/out/x.txt"}, resultPaths(t, root, results),
+		"Expected each real file to be found once")
+	assert.Len(t, searcher.seenDirs, 5, "Expected root, a, b, c and outside to be visited")
+}
+
+func TestSearchFollowLinksBeforeTarget(t *testing.T) {
+	root := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "a"), 0700), "Failed to create directory a")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "b"), 0700), "Failed to create directory b")
+	require.NoError(t, os.WriteFile(filepath.Join(root, "b", "b.txt"), nil, 0600), "Failed to write b.txt")
+	if err := os.Symlink("../b", filepath.Join(root, "a", "zz")); err != nil {
+		t.Skipf("Symlinks not supported: %v", err)
+	}
+
+	searcher, err := New(&Config{
+		Pattern:     "*.txt",
+		Include:     []string{root},
+		FollowLinks: true,
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+
+	results, err := searcher.Search()
+	require.NoError(t, err, "Expected no error searching")
+
+	// a/zz is walked before the real b/, so b's contents are reported
+	// once under the link path and the real directory is skipped
+	assert.Equal(t, []string{"a/zz/b.txt"}, resultPaths(t, root, results),
+		"Expected files to be reported under the first path that reaches them")
+}
+
+func TestSearchWithoutFollowLinks(t *testing.T) {
+	root := createSymlinkTree(t)
+
+	searcher, err := New(&Config{
+		Pattern: "*.txt",
+		Include: []string{root},
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+
+	results, err := searcher.Search()
+	require.NoError(t, err, "Expected no error searching")
+
+	assert.ElementsMatch(t, []string{"a/a.txt", "b/b.txt"}, resultPaths(t, root, results),
+		"Expected symlinked directories not to be traversed")
+}
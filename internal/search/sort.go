@@ -0,0 +1,131 @@
+package search
+
+import (
+	"container/heap"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Sort keys
+const (
+	// SortName orders results by base filename
+	SortName = "name"
+	// SortPath orders results by full path
+	SortPath = "path"
+	// SortSize orders results by size, smallest first
+	SortSize = "size"
+	// SortMtime orders results by modification time, oldest first
+	SortMtime = "mtime"
+)
+
+// resultLess reports whether a sorts before b
+type resultLess func(a, b *Result) bool
+
+// newResultLess returns the ordering for key. Ties are broken by path so
+// the order is deterministic; reverse inverts the whole ordering.
+func newResultLess(key string, reverse bool) (resultLess, error) {
+	var primary func(a, b *Result) int
+
+	switch key {
+	case SortName:
+		primary = func(a, b *Result) int {
+			return strings.Compare(filepath.Base(a.Path), filepath.Base(b.Path))
+		}
+	case SortPath:
+		primary = func(_, _ *Result) int {
+			return 0
+		}
+	case SortSize:
+		primary = func(a, b *Result) int {
+			switch {
+			case a.Size < b.Size:
+				return -1
+			case a.Size > b.Size:
+				return 1
+			default:
+				return 0
+			}
+		}
+	case SortMtime:
+		primary = func(a, b *Result) int {
+			return a.ModTime.Compare(b.ModTime)
+		}
+	default:
+		return nil, fmt.Errorf("invalid sort key %q: expected one of %s, %s, %s, %s",
+			key, SortName, SortPath, SortSize, SortMtime)
+	}
+
+	less := func(a, b *Result) bool {
+		if c := primary(a, b); c != 0 {
+			return c < 0
+		}
+		return a.Path < b.Path
+	}
+
+	if reverse {
+		return func(a, b *Result) bool {
+			return less(b, a)
+		}, nil
+	}
+	return less, nil
+}
+
+// Sort orders results in place by key (name, path, size or mtime),
+// in descending order when reverse is set
+func Sort(results []*Result, key string, reverse bool) error {
+	less, err := newResultLess(key, reverse)
+	if err != nil {
+		return err
+	}
+
+	sortResults(results, less)
+	return nil
+}
+
+// sortResults orders results in place by less
+func sortResults(results []*Result, less resultLess) {
+	sort.Slice(results, func(i, j int) bool {
+		return less(results[i], results[j])
+	})
+}
+
+// topResults keeps the first limit results of an ordering seen so far.
+// It is a heap whose root is the kept result that sorts last.
+type topResults struct {
+	items []*Result
+	less  resultLess
+	limit int
+}
+
+// Len implements heap.Interface
+func (t *topResults) Len() int { return len(t.items) }
+
+// Less implements heap.Interface, placing the result that sorts last at the root
+func (t *topResults) Less(i, j int) bool { return t.less(t.items[j], t.items[i]) }
+
+// Swap implements heap.Interface
+func (t *topResults) Swap(i, j int) { t.items[i], t.items[j] = t.items[j], t.items[i] }
+
+// Push implements heap.Interface
+func (t *topResults) Push(x any) { t.items = append(t.items, x.(*Result)) }
+
+// Pop implements heap.Interface
+func (t *topResults) Pop() any {
+	last := t.items[len(t.items)-1]
+	t.items = t.items[:len(t.items)-1]
+	return last
+}
+
+// add offers a result, evicting the current last result once the limit is reached
+func (t *topResults) add(result *Result) {
+	if len(t.items) < t.limit {
+		heap.Push(t, result)
+		return
+	}
+	if t.less(result, t.items[0]) {
+		t.items[0] = result
+		heap.Fix(t, 0)
+	}
+}
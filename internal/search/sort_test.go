@@ -0,0 +1,100 @@
+package search
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sortTestResults returns results whose orderings differ for every key
+func sortTestResults() []*Result {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	return []*Result{
+		{Path: "/b/zeta.txt", Size: 300, ModTime: base.Add(2 * time.Hour)},
+		{Path: "/a/alpha.txt", Size: 100, ModTime: base.Add(3 * time.Hour)},
+		{Path: "/c/beta.txt", Size: 200, ModTime: base.Add(time.Hour)},
+		{Path: "/a/gamma.txt", Size: 200, ModTime: base},
+	}
+}
+
+// sortedPaths returns the paths of results in order
+func sortedPaths(results []*Result) []string {
+	paths := make([]string, 0, len(results))
+	for _, result := range results {
+		paths = append(paths, result.Path)
+	}
+	return paths
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		key      string
+		reverse  bool
+		expected []string
+	}{
+		{SortName, false, []string{"/a/alpha.txt", "/c/beta.txt", "/a/gamma.txt", "/b/zeta.txt"}},
+		{SortName, true, []string{"/b/zeta.txt", "/a/gamma.txt", "/c/beta.txt", "/a/alpha.txt"}},
+		{SortPath, false, []string{"/a/alpha.txt", "/a/gamma.txt", "/b/zeta.txt", "/c/beta.txt"}},
+		{SortPath, true, []string{"/c/beta.txt", "/b/zeta.txt", "/a/gamma.txt", "/a/alpha.txt"}},
+		// Equal sizes are ordered by path
+		{SortSize, false, []string{"/a/alpha.txt", "/a/gamma.txt", "/c/beta.txt", "/b/zeta.txt"}},
+		{SortSize, true, []string{"/b/zeta.txt", "/c/beta.txt", "/a/gamma.txt", "/a/alpha.txt"}},
+		{SortMtime, false, []string{"/a/gamma.txt", "/c/beta.txt", "/b/zeta.txt", "/a/alpha.txt"}},
+		{SortMtime, true, []string{"/a/alpha.txt", "/b/zeta.txt", "/c/beta.txt", "/a/gamma.txt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s reverse=%v", tt.key, tt.reverse), func(t *testing.T) {
+			results := sortTestResults()
+			require.NoError(t, Sort(results, tt.key, tt.reverse), "Expected no error sorting")
+			assert.Equal(t, tt.expected, sortedPaths(results), "Sorted order should match expected")
+		})
+	}
+}
+
+func TestSortInvalidKey(t *testing.T) {
+	assert.Error(t, Sort(sortTestResults(), "color", false), "Expected error for invalid sort key")
+
+	_, err := New(&Config{Pattern: "*", Sort: "color"})
+	assert.Error(t, err, "Expected error for invalid sort key")
+
+	_, err = New(&Config{Pattern: "*", Reverse: true})
+	assert.Error(t, err, "Expected error for reverse without sort key")
+}
+
+func TestSearchSortedTopResults(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// File i is i*10 bytes, spread across directories so walk order differs from size order
+	const files = 60
+	for i := 0; i < files; i++ {
+		dir := filepath.Join(tempDir, fmt.Sprintf("dir%d", i%7))
+		require.NoError(t, os.MkdirAll(dir, 0700), "Failed to create directory %s", dir)
+
+		path := filepath.Join(dir, fmt.Sprintf("file%02d.dat", (i*37)%files))
+		require.NoError(t, os.WriteFile(path, make([]byte, i*10), 0600), "Failed to write %s", path)
+	}
+
+	searcher, err := New(&Config{
+		Pattern:    "*.dat",
+		Include:    []string{tempDir},
+		MaxResults: 10,
+		Sort:       SortSize,
+		Reverse:    true,
+	})
+	require.NoError(t, err, "Expected no error creating searcher")
+
+	results, err := searcher.Search()
+	require.NoError(t, err, "Expected no error searching")
+	require.Len(t, results, 10, "Expected results to be capped")
+
+	for i, result := range results {
+		assert.Equal(t, int64((files-1-i)*10), result.Size, "Expected the largest files in descending order")
+	}
+}